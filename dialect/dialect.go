@@ -0,0 +1,44 @@
+// Package dialect abstracts the SQL differences between database engines
+// so that manager can run migrations against Postgres, MySQL, SQLite,
+// ClickHouse or SQL Server without branching on the engine itself.
+package dialect
+
+// Store generates the dialect-specific statements manager needs to track
+// applied migrations and to serialize concurrent runs. Implementations
+// live in the dialectquery sub-package, one per supported engine.
+type Store interface {
+	// Name identifies the dialect, e.g. for logging.
+	Name() string
+	// CreateVersionTable returns the statement that creates the
+	// migrations bookkeeping table if it does not already exist.
+	CreateVersionTable(tableName string) string
+	// InsertVersion returns the statement, with dialect-appropriate
+	// placeholders, that records a migration as applied.
+	InsertVersion(tableName string) string
+	// DeleteVersion returns the statement that removes a migration
+	// record when rolling back.
+	DeleteVersion(tableName string) string
+	// ListMigrations returns the statement that lists applied migrations.
+	ListMigrations(tableName string) string
+	// LockTable returns a statement that takes a table-level lock for
+	// the duration of the enclosing transaction. ok is false for
+	// dialects that have no such concept (callers should fall back to
+	// AdvisoryLock or a documented no-op).
+	LockTable(tableName string) (query string, ok bool)
+	// AdvisoryLock returns a statement (and its argument) that acquires
+	// a session-scoped lock keyed off tableName, held independently of
+	// any transaction. ok is false when the dialect has no such
+	// mechanism.
+	//
+	// The query is run with QueryRowContext and its single result column
+	// is passed to check, which must return a non-nil error when the
+	// value indicates the lock was NOT actually acquired - e.g. MySQL's
+	// GET_LOCK returning NULL, or SQL Server's sp_getapplock returning a
+	// negative code - even though running the query itself produced no
+	// driver error. check is nil for dialects whose lock statement
+	// blocks until acquired and returns nothing useful to check (e.g.
+	// Postgres' pg_advisory_lock), so any successful exec is genuine.
+	AdvisoryLock(tableName string) (query string, arg interface{}, check func(result interface{}) error, ok bool)
+	// AdvisoryUnlock releases a lock acquired via AdvisoryLock.
+	AdvisoryUnlock(tableName string) (query string, arg interface{}, ok bool)
+}
@@ -0,0 +1,39 @@
+package dialectquery
+
+import "fmt"
+
+// ClickHouse implements dialect.Store for ClickHouse.
+type ClickHouse struct{}
+
+func (ClickHouse) Name() string { return "clickhouse" }
+
+func (ClickHouse) CreateVersionTable(tableName string) string {
+	return fmt.Sprintf(`CREATE TABLE IF NOT EXISTS "%s" (version UInt64, created_at DateTime) ENGINE = MergeTree ORDER BY version;`, tableName)
+}
+
+func (ClickHouse) InsertVersion(tableName string) string {
+	return fmt.Sprintf(`INSERT INTO "%s" (version, created_at) VALUES (?, ?);`, tableName)
+}
+
+func (ClickHouse) DeleteVersion(tableName string) string {
+	return fmt.Sprintf(`ALTER TABLE "%s" DELETE WHERE version = ?;`, tableName)
+}
+
+func (ClickHouse) ListMigrations(tableName string) string {
+	return fmt.Sprintf(`SELECT version, created_at FROM "%s";`, tableName)
+}
+
+// LockTable is unsupported: ClickHouse has no table-level lock statement.
+func (ClickHouse) LockTable(_ string) (string, bool) {
+	return "", false
+}
+
+// AdvisoryLock is unsupported: ClickHouse has no session lock primitive.
+// Concurrent runs are not serialized; callers should document this.
+func (ClickHouse) AdvisoryLock(_ string) (string, interface{}, func(interface{}) error, bool) {
+	return "", nil, nil, false
+}
+
+func (ClickHouse) AdvisoryUnlock(_ string) (string, interface{}, bool) {
+	return "", nil, false
+}
@@ -0,0 +1,112 @@
+package dialectquery
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/slmder/migrate/dialect"
+)
+
+var stores = map[string]dialect.Store{
+	"postgres":   Postgres{},
+	"mysql":      MySQL{},
+	"sqlite":     SQLite{},
+	"clickhouse": ClickHouse{},
+	"sqlserver":  SQLServer{},
+}
+
+func TestStoreNames(t *testing.T) {
+	for name, store := range stores {
+		if store.Name() != name {
+			t.Errorf("%T.Name() = %q, want %q", store, store.Name(), name)
+		}
+	}
+}
+
+func TestLockKeyStable(t *testing.T) {
+	a := LockKey("schema_migrations")
+	b := LockKey("schema_migrations")
+	if a != b {
+		t.Fatalf("LockKey not stable across calls: %d != %d", a, b)
+	}
+	if LockKey("schema_migrations") == LockKey("other_table") {
+		t.Fatal("LockKey should differ for different table names")
+	}
+}
+
+func TestSQLServerAdvisoryLockIsParameterized(t *testing.T) {
+	query, arg, check, ok := SQLServer{}.AdvisoryLock("schema_migrations")
+	if !ok {
+		t.Fatal("SQLServer.AdvisoryLock() ok = false, want true")
+	}
+	if arg != "schema_migrations" {
+		t.Fatalf("SQLServer.AdvisoryLock() arg = %v, want the table name", arg)
+	}
+	if !strings.Contains(query, "@p1") {
+		t.Fatalf("SQLServer.AdvisoryLock() query = %q, want a @p1 placeholder", query)
+	}
+	if check == nil {
+		t.Fatal("SQLServer.AdvisoryLock() check = nil, want a result checker for sp_getapplock's return code")
+	}
+
+	unlockQuery, unlockArg, ok := SQLServer{}.AdvisoryUnlock("schema_migrations")
+	if !ok {
+		t.Fatal("SQLServer.AdvisoryUnlock() ok = false, want true")
+	}
+	if unlockArg != "schema_migrations" {
+		t.Fatalf("SQLServer.AdvisoryUnlock() arg = %v, want the table name", unlockArg)
+	}
+	if !strings.Contains(unlockQuery, "@p1") {
+		t.Fatalf("SQLServer.AdvisoryUnlock() query = %q, want a @p1 placeholder", unlockQuery)
+	}
+}
+
+func TestSQLServerAdvisoryLockCheckRejectsNegativeCode(t *testing.T) {
+	_, _, check, _ := SQLServer{}.AdvisoryLock("schema_migrations")
+	if err := check(int64(0)); err != nil {
+		t.Fatalf("check(0) error = %v, want nil", err)
+	}
+	if err := check(int64(-3)); err == nil {
+		t.Fatal("check(-3) error = nil, want error for a deadlock-victim return code")
+	}
+}
+
+func TestMySQLAdvisoryLockCheckRejectsNonOne(t *testing.T) {
+	_, _, check, _ := MySQL{}.AdvisoryLock("schema_migrations")
+	if err := check(int64(1)); err != nil {
+		t.Fatalf("check(1) error = %v, want nil", err)
+	}
+	if err := check(nil); err == nil {
+		t.Fatal("check(nil) error = nil, want error since GET_LOCK returns NULL on failure")
+	}
+}
+
+func TestPostgresAdvisoryLockBlocks(t *testing.T) {
+	query, arg, check, ok := Postgres{}.AdvisoryLock("schema_migrations")
+	if !ok {
+		t.Fatal("Postgres.AdvisoryLock() ok = false, want true")
+	}
+	if arg != LockKey("schema_migrations") {
+		t.Fatalf("Postgres.AdvisoryLock() arg = %v, want LockKey(table)", arg)
+	}
+	if strings.Contains(query, "pg_try_advisory_lock") {
+		t.Fatalf("Postgres.AdvisoryLock() = %q, want the blocking pg_advisory_lock, not pg_try_advisory_lock", query)
+	}
+	if check != nil {
+		t.Fatal("Postgres.AdvisoryLock() check != nil, want nil since the query blocks until acquired")
+	}
+}
+
+// TestDialectsWithoutAdvisoryLockSupport documents the engines with no
+// session lock primitive: both AdvisoryLock and AdvisoryUnlock must report
+// ok = false rather than returning a statement the caller can't use.
+func TestDialectsWithoutAdvisoryLockSupport(t *testing.T) {
+	for _, d := range []dialect.Store{SQLite{}, ClickHouse{}} {
+		if _, _, _, ok := d.AdvisoryLock("schema_migrations"); ok {
+			t.Errorf("%s.AdvisoryLock() ok = true, want false", d.Name())
+		}
+		if _, _, ok := d.AdvisoryUnlock("schema_migrations"); ok {
+			t.Errorf("%s.AdvisoryUnlock() ok = true, want false", d.Name())
+		}
+	}
+}
@@ -0,0 +1,14 @@
+package dialectquery
+
+import "hash/fnv"
+
+// LockKey derives a stable 64-bit key from a (schema-qualified) table name
+// for use with advisory/session lock primitives, e.g. Postgres'
+// pg_advisory_lock, which takes a numeric key. MySQL's GET_LOCK uses it
+// formatted as a decimal string; SQL Server's sp_getapplock takes the
+// table name itself and does not use LockKey.
+func LockKey(tableName string) int64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(tableName))
+	return int64(h.Sum64())
+}
@@ -0,0 +1,29 @@
+package dialectquery
+
+import "fmt"
+
+// asInt64 normalizes the driver value scanned from a lock-acquisition
+// query into an int64, since drivers disagree on whether an integer
+// column surfaces as int64, []byte or string.
+func asInt64(value interface{}) (int64, bool) {
+	switch v := value.(type) {
+	case int64:
+		return v, true
+	case int32:
+		return int64(v), true
+	case []byte:
+		var n int64
+		if _, err := fmt.Sscanf(string(v), "%d", &n); err != nil {
+			return 0, false
+		}
+		return n, true
+	case string:
+		var n int64
+		if _, err := fmt.Sscanf(v, "%d", &n); err != nil {
+			return 0, false
+		}
+		return n, true
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,52 @@
+package dialectquery
+
+import "fmt"
+
+// MySQL implements dialect.Store for MySQL/MariaDB.
+type MySQL struct{}
+
+func (MySQL) Name() string { return "mysql" }
+
+func (MySQL) CreateVersionTable(tableName string) string {
+	return fmt.Sprintf("CREATE TABLE IF NOT EXISTS `%s` (version BIGINT UNIQUE, created_at TIMESTAMP);", tableName)
+}
+
+func (MySQL) InsertVersion(tableName string) string {
+	return fmt.Sprintf("INSERT INTO `%s` (version, created_at) VALUES (?, ?);", tableName)
+}
+
+func (MySQL) DeleteVersion(tableName string) string {
+	return fmt.Sprintf("DELETE FROM `%s` WHERE version = ?;", tableName)
+}
+
+func (MySQL) ListMigrations(tableName string) string {
+	return fmt.Sprintf("SELECT version, created_at FROM `%s`;", tableName)
+}
+
+// LockTable is unsupported: MySQL's LOCK TABLES releases any lock held by
+// the session on commit and conflicts with the transactional semantics
+// manager relies on elsewhere, so callers should fall back to
+// AdvisoryLock.
+func (MySQL) LockTable(_ string) (string, bool) {
+	return "", false
+}
+
+// AdvisoryLock's GET_LOCK blocks with a -1 (infinite) timeout, but still
+// returns NULL rather than erroring if the lock could not be obtained at
+// all (e.g. the session was killed mid-wait), so the result must be
+// checked rather than just the absence of a driver error.
+func (MySQL) AdvisoryLock(tableName string) (string, interface{}, func(interface{}) error, bool) {
+	return `SELECT GET_LOCK(?, -1);`, fmt.Sprintf("%d", LockKey(tableName)), checkGetLockResult, true
+}
+
+func (MySQL) AdvisoryUnlock(tableName string) (string, interface{}, bool) {
+	return `SELECT RELEASE_LOCK(?);`, fmt.Sprintf("%d", LockKey(tableName)), true
+}
+
+// checkGetLockResult rejects anything but GET_LOCK returning 1.
+func checkGetLockResult(value interface{}) error {
+	if n, ok := asInt64(value); ok && n == 1 {
+		return nil
+	}
+	return fmt.Errorf("migrate: GET_LOCK did not return 1 (got %v)", value)
+}
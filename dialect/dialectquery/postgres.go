@@ -0,0 +1,42 @@
+package dialectquery
+
+import "fmt"
+
+// Postgres implements dialect.Store for PostgreSQL.
+type Postgres struct{}
+
+func (Postgres) Name() string { return "postgres" }
+
+func (Postgres) CreateVersionTable(tableName string) string {
+	return fmt.Sprintf(`CREATE TABLE IF NOT EXISTS "%s" (version BIGINT UNIQUE, created_at TIMESTAMP);`, tableName)
+}
+
+func (Postgres) InsertVersion(tableName string) string {
+	return fmt.Sprintf(`INSERT INTO "%s" (version, created_at) VALUES ($1, $2);`, tableName)
+}
+
+func (Postgres) DeleteVersion(tableName string) string {
+	return fmt.Sprintf(`DELETE FROM "%s" WHERE version = $1;`, tableName)
+}
+
+func (Postgres) ListMigrations(tableName string) string {
+	return fmt.Sprintf(`SELECT version, created_at FROM "%s";`, tableName)
+}
+
+func (Postgres) LockTable(tableName string) (string, bool) {
+	return fmt.Sprintf(`LOCK TABLE "%s" IN SHARE MODE;`, tableName), true
+}
+
+// AdvisoryLock uses the blocking pg_advisory_lock rather than
+// pg_try_advisory_lock: the latter returns a boolean that the caller must
+// inspect (it never errors just because the lock is held elsewhere), so
+// it would need a check func to reject a false result, and a second run
+// would then fail instead of waiting. Blocking until the lock is free
+// needs no result check, so no check func is returned here.
+func (Postgres) AdvisoryLock(tableName string) (string, interface{}, func(interface{}) error, bool) {
+	return `SELECT pg_advisory_lock($1);`, LockKey(tableName), nil, true
+}
+
+func (Postgres) AdvisoryUnlock(tableName string) (string, interface{}, bool) {
+	return `SELECT pg_advisory_unlock($1);`, LockKey(tableName), true
+}
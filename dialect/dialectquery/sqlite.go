@@ -0,0 +1,41 @@
+package dialectquery
+
+import "fmt"
+
+// SQLite implements dialect.Store for SQLite.
+type SQLite struct{}
+
+func (SQLite) Name() string { return "sqlite" }
+
+func (SQLite) CreateVersionTable(tableName string) string {
+	return fmt.Sprintf(`CREATE TABLE IF NOT EXISTS "%s" (version BIGINT UNIQUE, created_at TIMESTAMP);`, tableName)
+}
+
+func (SQLite) InsertVersion(tableName string) string {
+	return fmt.Sprintf(`INSERT INTO "%s" (version, created_at) VALUES (?, ?);`, tableName)
+}
+
+func (SQLite) DeleteVersion(tableName string) string {
+	return fmt.Sprintf(`DELETE FROM "%s" WHERE version = ?;`, tableName)
+}
+
+func (SQLite) ListMigrations(tableName string) string {
+	return fmt.Sprintf(`SELECT version, created_at FROM "%s";`, tableName)
+}
+
+// LockTable is unsupported: SQLite has no table-level lock statement,
+// serialization comes from the single-writer nature of the file itself.
+func (SQLite) LockTable(_ string) (string, bool) {
+	return "", false
+}
+
+// AdvisoryLock is unsupported: SQLite has no session-scoped lock
+// primitive. Callers should document that concurrent runs against the
+// same file are not serialized beyond SQLite's own file locking.
+func (SQLite) AdvisoryLock(_ string) (string, interface{}, func(interface{}) error, bool) {
+	return "", nil, nil, false
+}
+
+func (SQLite) AdvisoryUnlock(_ string) (string, interface{}, bool) {
+	return "", nil, false
+}
@@ -0,0 +1,53 @@
+package dialectquery
+
+import "fmt"
+
+// SQLServer implements dialect.Store for Microsoft SQL Server.
+type SQLServer struct{}
+
+func (SQLServer) Name() string { return "sqlserver" }
+
+func (SQLServer) CreateVersionTable(tableName string) string {
+	return fmt.Sprintf(`IF OBJECT_ID('[%s]', 'U') IS NULL CREATE TABLE [%s] (version BIGINT UNIQUE, created_at DATETIME);`, tableName, tableName)
+}
+
+func (SQLServer) InsertVersion(tableName string) string {
+	return fmt.Sprintf(`INSERT INTO [%s] (version, created_at) VALUES (@p1, @p2);`, tableName)
+}
+
+func (SQLServer) DeleteVersion(tableName string) string {
+	return fmt.Sprintf(`DELETE FROM [%s] WHERE version = @p1;`, tableName)
+}
+
+func (SQLServer) ListMigrations(tableName string) string {
+	return fmt.Sprintf(`SELECT version, created_at FROM [%s];`, tableName)
+}
+
+// LockTable takes a table-level exclusive lock held for the transaction.
+func (SQLServer) LockTable(tableName string) (string, bool) {
+	return fmt.Sprintf(`SELECT TOP 0 1 FROM [%s] WITH (TABLOCKX, HOLDLOCK);`, tableName), true
+}
+
+// AdvisoryLock captures sp_getapplock's return code into @result and
+// selects it back: the default infinite @LockTimeout still returns a
+// negative code (e.g. -3 deadlock victim, -999 parameter/other error)
+// instead of erroring, so the code must be checked rather than just the
+// absence of a driver error.
+func (SQLServer) AdvisoryLock(tableName string) (string, interface{}, func(interface{}) error, bool) {
+	return `DECLARE @result int; ` +
+		`EXEC @result = sp_getapplock @Resource = @p1, @LockMode = 'Exclusive', @LockOwner = 'Session'; ` +
+		`SELECT @result;`, tableName, checkApplockResult, true
+}
+
+func (SQLServer) AdvisoryUnlock(tableName string) (string, interface{}, bool) {
+	return `EXEC sp_releaseapplock @Resource = @p1, @LockOwner = 'Session';`, tableName, true
+}
+
+// checkApplockResult rejects any negative sp_getapplock return code.
+func checkApplockResult(value interface{}) error {
+	n, ok := asInt64(value)
+	if !ok || n < 0 {
+		return fmt.Errorf("migrate: sp_getapplock returned %v, want >= 0", value)
+	}
+	return nil
+}
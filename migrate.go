@@ -7,6 +7,8 @@ import (
 	"errors"
 	"fmt"
 	"github.com/jmoiron/sqlx"
+	"github.com/slmder/migrate/dialect"
+	"github.com/slmder/migrate/dialect/dialectquery"
 	"io/fs"
 	"io/ioutil"
 	"os"
@@ -34,6 +36,26 @@ const (
 	TransactionModeIndividual
 )
 
+// LockStrategy controls how manager serializes concurrent runs against
+// the same schema_migrations table.
+type LockStrategy int
+
+const (
+	// LockAdvisory takes a session-scoped advisory lock (e.g. Postgres'
+	// blocking pg_advisory_lock, which waits for a lock held elsewhere
+	// rather than failing) on a dedicated connection held for the
+	// whole run, rather than a lock tied to a single transaction.
+	LockAdvisory LockStrategy = iota
+	// LockTable takes a table-level lock inside each transaction, via
+	// dialect.Store.LockTable. This is the legacy behavior and is
+	// weaker: it only guards one transaction at a time, and some
+	// lock modes (e.g. Postgres SHARE) permit other lockers through.
+	LockTable
+	// LockNone disables locking entirely; the caller is responsible
+	// for serializing runs out of band.
+	LockNone
+)
+
 type Logger interface {
 	Infof(format string, args ...interface{})
 	Errorf(format string, args ...interface{})
@@ -44,52 +66,306 @@ type Version interface {
 	Down(transaction *sqlx.Tx) error
 }
 
+// Collection is an ordered set of Version migrations whose version
+// number is derived from the Go type name via reflection (versionIndex).
+//
+// Deprecated: reflection-based version extraction breaks silently for
+// pointer receivers on anonymous structs, renamed types, or builds with
+// -trimpath, and can't detect duplicate versions until run time. Prefer
+// Register/RegisterSQL with PrepareRegistered.
 type Collection []Version
 
 func (a *Collection) Add(m Version) {
 	*a = append(*a, m)
 }
 
+// Migration is a single up/down migration addressed by a version shared
+// across sources, regardless of whether it originated as a compiled Go
+// Version or a .sql file read by a Source.
+type Migration struct {
+	Version uint64
+	Name    string
+	Source  string
+	Up      func(tx *sqlx.Tx) error
+	Down    func(tx *sqlx.Tx) error
+}
+
+// Source produces the ordered set of migrations a Manager should run.
+// GoSource adapts a Collection of Version; other implementations (e.g.
+// FSSource in the source package) read migrations from an fs.FS.
+type Source interface {
+	Migrations() ([]Migration, error)
+}
+
+// GoSource adapts a Collection of compiled Go Version values into a
+// Source, deriving each Migration's version via versionIndex.
+//
+// Deprecated: see Collection. Prefer Register/RegisterSQL with
+// PrepareRegistered, which store {Version, Name, Up, Down} directly and
+// need no reflection.
+type GoSource struct {
+	versions Collection
+}
+
+// NewGoSource wraps versions as a Source.
+func NewGoSource(versions Collection) *GoSource {
+	return &GoSource{versions: versions}
+}
+
+func (s *GoSource) Migrations() ([]Migration, error) {
+	migrations := make([]Migration, 0, len(s.versions))
+	for _, version := range s.versions {
+		index, err := versionIndex(version)
+		if err != nil {
+			return nil, err
+		}
+		version := version
+		migrations = append(migrations, Migration{
+			Version: index,
+			Source:  "go",
+			Up:      version.Up,
+			Down:    version.Down,
+		})
+	}
+	return migrations, nil
+}
+
+// registry accumulates migrations added via Register/RegisterSQL. It is
+// package-level so migration files can register themselves from an
+// init() without needing to be wired into a Collection by hand.
+var registry []Migration
+
+// Register adds a migration to the package-level registry with an
+// explicit version and name, rather than deriving the version from the
+// Go type name via reflection. Typically called from a migration file's
+// init(). Duplicate versions are rejected when the registry is consumed
+// by PrepareRegistered, not here, so registration order across files
+// does not matter.
+func Register(version uint64, name string, up, down func(tx *sqlx.Tx) error) {
+	registry = append(registry, Migration{Version: version, Name: name, Source: "go", Up: up, Down: down})
+}
+
+// RegisterSQL adds a migration backed by raw SQL scripts to the
+// package-level registry, splitting each script into statements with
+// SplitStatements - the same rules FSSource applies to .sql files.
+func RegisterSQL(version uint64, name string, up, down string) {
+	Register(version, name, execSQL(up), execSQL(down))
+}
+
+func execSQL(script string) func(tx *sqlx.Tx) error {
+	return func(tx *sqlx.Tx) error {
+		for _, stmt := range SplitStatements(script) {
+			if _, err := tx.Exec(stmt); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// registrySource adapts the package-level registry into a Source,
+// detecting duplicate versions eagerly rather than at run time.
+type registrySource struct{}
+
+func (registrySource) Migrations() ([]Migration, error) {
+	seenNames := make(map[uint64]string, len(registry))
+	migrations := make([]Migration, 0, len(registry))
+	for _, mig := range registry {
+		if name, dup := seenNames[mig.Version]; dup {
+			return nil, fmt.Errorf("migrate: duplicate version %d (registered as %q and %q)", mig.Version, name, mig.Name)
+		}
+		seenNames[mig.Version] = mig.Name
+		migrations = append(migrations, mig)
+	}
+	return migrations, nil
+}
+
 type PassedMigration struct {
 	Version   uint64    `db:"version"`
 	CreatedAt time.Time `db:"created_at"`
 }
 
+// MigrationStatus reports whether a migration known to the configured
+// Source has been applied, as returned by Manager.Status.
+type MigrationStatus struct {
+	Version   uint64
+	Applied   bool
+	AppliedAt time.Time
+	Source    string
+}
+
 type Manager interface {
+	// Prepare configures the manager with a reflection-based Collection.
+	//
+	// Deprecated: prefer Register/RegisterSQL with PrepareRegistered.
 	Prepare(versions Collection) error
+	PrepareSource(src Source) error
+	// PrepareRegistered configures the manager to run every migration
+	// added via Register/RegisterSQL, rejecting duplicate versions
+	// immediately instead of deferring to Up/Down.
+	PrepareRegistered() error
 	Up(ctx context.Context, mode TransactionMode) error
 	Down(ctx context.Context, mode TransactionMode) error
+	UpTo(ctx context.Context, version uint64, mode TransactionMode) error
+	DownTo(ctx context.Context, version uint64, mode TransactionMode) error
+	Redo(ctx context.Context, mode TransactionMode) error
+	Status(ctx context.Context) ([]MigrationStatus, error)
 	Generate() error
 	Lookup(versions Collection, versionNames ...string) (Collection, error)
 }
 
+// HookFunc is invoked by manager.run around each migration, in the same
+// transaction (or, in TransactionModeGeneral, the same shared
+// transaction) the migration itself runs in.
+type HookFunc func(ctx context.Context, tx *sqlx.Tx, version uint64, direction Direction) error
+
+// call invokes fn if it is set, treating a nil HookFunc as a no-op.
+func (fn HookFunc) call(ctx context.Context, tx *sqlx.Tx, version uint64, direction Direction) error {
+	if fn == nil {
+		return nil
+	}
+	return fn(ctx, tx, version, direction)
+}
+
+// OnErrorFunc is invoked by manager.run when a hook or a migration itself
+// returns an error, before the enclosing transaction is rolled back: the
+// migration's own transaction right away (TransactionModeIndividual), or
+// the shared transaction when the run returns (TransactionModeGeneral).
+type OnErrorFunc func(ctx context.Context, tx *sqlx.Tx, version uint64, direction Direction, cause error)
+
+// hooks holds the lifecycle callbacks configured via WithBeforeUp,
+// WithAfterUp, WithBeforeDown, WithAfterDown and WithOnError.
+type hooks struct {
+	beforeUp   HookFunc
+	afterUp    HookFunc
+	beforeDown HookFunc
+	afterDown  HookFunc
+	onError    OnErrorFunc
+}
+
 type manager struct {
 	logger         Logger
-	versions       Collection
+	source         Source
 	conn           *sqlx.DB
 	migrationsPath string
 	templatePath   string
 	tableName      string
+	dialect        dialect.Store
+	lockStrategy   LockStrategy
+	hooks          hooks
+}
+
+// Option configures optional manager behavior, e.g. WithDialect.
+type Option func(*manager)
+
+// WithDialect selects the SQL dialect store used to generate the
+// statements manager needs to track and serialize migrations. Defaults
+// to dialectquery.Postgres when not given.
+func WithDialect(d dialect.Store) Option {
+	return func(m *manager) {
+		m.dialect = d
+	}
+}
+
+// WithLockStrategy selects how manager serializes concurrent runs.
+// Defaults to LockAdvisory.
+func WithLockStrategy(strategy LockStrategy) Option {
+	return func(m *manager) {
+		m.lockStrategy = strategy
+	}
+}
+
+// WithBeforeUp registers a hook invoked before each migration is applied,
+// in the same transaction the migration itself will run in. A returned
+// error aborts the run without applying the migration.
+func WithBeforeUp(fn HookFunc) Option {
+	return func(m *manager) {
+		m.hooks.beforeUp = fn
+	}
+}
+
+// WithAfterUp registers a hook invoked after each migration is applied
+// and recorded, in the same transaction. A returned error aborts the
+// run and rolls back the migration's transaction rather than committing
+// it, whether that transaction is scoped to the one migration
+// (TransactionModeIndividual) or shared across the whole run
+// (TransactionModeGeneral).
+func WithAfterUp(fn HookFunc) Option {
+	return func(m *manager) {
+		m.hooks.afterUp = fn
+	}
 }
 
-func NewManager(migrationsDir, templatePath string, logger Logger, tableName string, conn *sqlx.DB) Manager {
+// WithBeforeDown registers a hook invoked before each migration is
+// rolled back, in the same transaction. A returned error aborts the run
+// without rolling back the migration.
+func WithBeforeDown(fn HookFunc) Option {
+	return func(m *manager) {
+		m.hooks.beforeDown = fn
+	}
+}
+
+// WithAfterDown registers a hook invoked after each migration is rolled
+// back and its record removed, in the same transaction.
+func WithAfterDown(fn HookFunc) Option {
+	return func(m *manager) {
+		m.hooks.afterDown = fn
+	}
+}
+
+// WithOnError registers a callback invoked whenever a migration or one
+// of its hooks fails, before the enclosing transaction is rolled back.
+// Typical uses are metrics/alerting; the callback cannot suppress the
+// error.
+func WithOnError(fn OnErrorFunc) Option {
+	return func(m *manager) {
+		m.hooks.onError = fn
+	}
+}
+
+func NewManager(migrationsDir, templatePath string, logger Logger, tableName string, conn *sqlx.DB, opts ...Option) Manager {
 	if tableName == "" {
 		tableName = defTableName
 	}
-	return &manager{
+	m := &manager{
 		migrationsPath: migrationsDir,
 		templatePath:   templatePath,
 		logger:         logger,
 		tableName:      tableName,
 		conn:           conn,
+		dialect:        dialectquery.Postgres{},
+		lockStrategy:   LockAdvisory,
 	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
 }
 
 func (m *manager) Prepare(versions Collection) error {
 	if versions == nil {
 		return errors.New("versions must not be nil")
 	}
-	m.versions = versions
+	m.source = NewGoSource(versions)
+	return nil
+}
+
+// PrepareSource configures the manager with an arbitrary Source, e.g. an
+// FSSource reading .sql files instead of compiled Go Version types.
+func (m *manager) PrepareSource(src Source) error {
+	if src == nil {
+		return errors.New("source must not be nil")
+	}
+	m.source = src
+	return nil
+}
+
+func (m *manager) PrepareRegistered() error {
+	src := registrySource{}
+	if _, err := src.Migrations(); err != nil {
+		return err
+	}
+	m.source = src
 	return nil
 }
 
@@ -114,11 +390,93 @@ func (m *manager) Lookup(versions Collection, versionNames ...string) (Collectio
 }
 
 func (m *manager) Up(ctx context.Context, mode TransactionMode) error {
-	return m.run(ctx, DirectionUp, mode)
+	return m.run(ctx, DirectionUp, mode, nil)
 }
 
 func (m *manager) Down(ctx context.Context, mode TransactionMode) error {
-	return m.run(ctx, DirectionDown, mode)
+	return m.run(ctx, DirectionDown, mode, nil)
+}
+
+// UpTo applies pending migrations up to and including version.
+func (m *manager) UpTo(ctx context.Context, version uint64, mode TransactionMode) error {
+	return m.run(ctx, DirectionUp, mode, func(v uint64) bool { return v <= version })
+}
+
+// DownTo rolls back applied migrations newer than version, leaving
+// version (if applied) and everything older in place.
+func (m *manager) DownTo(ctx context.Context, version uint64, mode TransactionMode) error {
+	return m.run(ctx, DirectionDown, mode, func(v uint64) bool { return v > version })
+}
+
+// Redo rolls back and reapplies the latest applied migration. The down
+// and up halves share a single acquireLock call, rather than one each, so
+// under LockAdvisory a concurrent migrate process can't interleave between
+// them. Under LockTable each half locks the table only within its own
+// transactions, and LockNone takes no lock.
+func (m *manager) Redo(ctx context.Context, mode TransactionMode) error {
+	if m.source == nil {
+		return errors.New("migration source not provided, call Prepare or PrepareSource")
+	}
+	if err := m.createTableIfNotExists(); err != nil {
+		return err
+	}
+	releaseLock, err := m.acquireLock(ctx)
+	if err != nil {
+		return err
+	}
+	defer releaseLock()
+
+	passedMigrations, err := m.findAll()
+	if err != nil {
+		return err
+	}
+	if len(passedMigrations) == 0 {
+		return nil
+	}
+	latest := passedMigrations[0].Version
+	for _, p := range passedMigrations[1:] {
+		if p.Version > latest {
+			latest = p.Version
+		}
+	}
+	only := func(v uint64) bool { return v == latest }
+	if err := m.runLocked(ctx, DirectionDown, mode, only); err != nil {
+		return err
+	}
+	return m.runLocked(ctx, DirectionUp, mode, only)
+}
+
+// Status reports, for every migration known to the configured Source,
+// whether it has been applied and when, joined against the
+// schema_migrations table.
+func (m *manager) Status(_ context.Context) ([]MigrationStatus, error) {
+	if m.source == nil {
+		return nil, errors.New("migration source not provided, call Prepare or PrepareSource")
+	}
+	if err := m.createTableIfNotExists(); err != nil {
+		return nil, err
+	}
+	migrations, err := m.source.Migrations()
+	if err != nil {
+		return nil, err
+	}
+	migrations = sortMigrations(migrations, DirectionUp)
+	passedMigrations, err := m.findAll()
+	if err != nil {
+		return nil, err
+	}
+	passedMigrationsMap := toMap(passedMigrations)
+
+	statuses := make([]MigrationStatus, 0, len(migrations))
+	for _, migration := range migrations {
+		status := MigrationStatus{Version: migration.Version, Source: migration.Source}
+		if passed, ok := passedMigrationsMap[migration.Version]; ok {
+			status.Applied = true
+			status.AppliedAt = passed.CreatedAt
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
 }
 
 func (m *manager) Generate() error {
@@ -167,14 +525,36 @@ func (m *manager) Generate() error {
 	return nil
 }
 
-func (m *manager) run(ctx context.Context, direction Direction, mode TransactionMode) (er error) {
-	if m.versions == nil {
-		return errors.New("versions collection not provided, provide versions collection")
+// run applies or rolls back migrations from the configured source,
+// acquiring the lock for the duration of this single invocation. When
+// target is non-nil, only migrations whose version satisfies it are
+// considered; this backs UpTo/DownTo, while Up/Down pass a nil target to
+// act on every migration. Redo instead calls runLocked directly, twice,
+// under a single lock acquisition spanning both the down and up halves.
+func (m *manager) run(ctx context.Context, direction Direction, mode TransactionMode, target func(version uint64) bool) error {
+	if m.source == nil {
+		return errors.New("migration source not provided, call Prepare or PrepareSource")
 	}
 	if err := m.createTableIfNotExists(); err != nil {
 		return err
 	}
-	versions := sortMigrations(m.versions, direction)
+	releaseLock, err := m.acquireLock(ctx)
+	if err != nil {
+		return err
+	}
+	defer releaseLock()
+	return m.runLocked(ctx, direction, mode, target)
+}
+
+// runLocked is the lock-agnostic body of run: it assumes the caller has
+// already created the version table and holds whatever lock the
+// configured LockStrategy requires.
+func (m *manager) runLocked(ctx context.Context, direction Direction, mode TransactionMode, target func(version uint64) bool) (er error) {
+	migrations, err := m.source.Migrations()
+	if err != nil {
+		return err
+	}
+	migrations = sortMigrations(migrations, direction)
 	passedMigrations, err := m.findAll()
 	if err != nil {
 		return err
@@ -186,40 +566,55 @@ func (m *manager) run(ctx context.Context, direction Direction, mode Transaction
 		if err != nil {
 			return err
 		}
-		if err := m.lockTable(tx); err != nil {
-			return err
+		if m.lockStrategy == LockTable {
+			if err := m.lockTable(tx); err != nil {
+				return err
+			}
 		}
-		defer func(tx *sqlx.Tx, err error) {
-			err = tx.Commit()
-			if err != nil {
+		defer func(tx *sqlx.Tx) {
+			if er != nil {
+				if rbErr := tx.Rollback(); rbErr != nil {
+					m.logger.Errorf("migration: rollback err: %s", rbErr)
+				}
+				return
+			}
+			if err := tx.Commit(); err != nil {
 				m.logger.Errorf("Transaction commit err: %s", err)
 			}
-		}(tx, er)
+		}(tx)
 	}
-	for _, version := range versions {
+	for _, migration := range migrations {
+		if target != nil && !target(migration.Version) {
+			continue
+		}
 		if TransactionModeIndividual == mode {
 			tx, err = m.conn.BeginTxx(ctx, &sql.TxOptions{Isolation: sql.LevelSerializable})
 			if err != nil {
 				return err
 			}
-			if err := m.lockTable(tx); err != nil {
-				return err
+			if m.lockStrategy == LockTable {
+				if err := m.lockTable(tx); err != nil {
+					return err
+				}
 			}
 		}
-		index, err := versionIndex(version)
-		if err != nil {
-			return err
-		}
-		migration := PassedMigration{Version: index, CreatedAt: time.Now()}
+		index := migration.Version
+		passedMigration := PassedMigration{Version: index, CreatedAt: time.Now()}
 		_, passed := passedMigrationsMap[index]
 		if direction == DirectionUp {
 			if !passed {
 				m.logger.Infof("upgrading to version %d...", index)
-				if err := version.Up(tx); err != nil {
-					return err
+				if err := m.hooks.beforeUp.call(ctx, tx, index, direction); err != nil {
+					return m.fail(ctx, tx, mode, index, direction, err)
 				}
-				if err := m.insert(tx, migration); err != nil {
-					return err
+				if err := migration.Up(tx); err != nil {
+					return m.fail(ctx, tx, mode, index, direction, err)
+				}
+				if err := m.insert(tx, passedMigration); err != nil {
+					return m.fail(ctx, tx, mode, index, direction, err)
+				}
+				if err := m.hooks.afterUp.call(ctx, tx, index, direction); err != nil {
+					return m.fail(ctx, tx, mode, index, direction, err)
 				}
 			} else {
 				m.logger.Infof("skipping passed version %d...", index)
@@ -227,11 +622,17 @@ func (m *manager) run(ctx context.Context, direction Direction, mode Transaction
 		} else {
 			if passed {
 				m.logger.Infof("downgrading version %d...", index)
-				if err := version.Down(tx); err != nil {
-					return err
+				if err := m.hooks.beforeDown.call(ctx, tx, index, direction); err != nil {
+					return m.fail(ctx, tx, mode, index, direction, err)
 				}
-				if err := m.delete(tx, migration); err != nil {
-					return err
+				if err := migration.Down(tx); err != nil {
+					return m.fail(ctx, tx, mode, index, direction, err)
+				}
+				if err := m.delete(tx, passedMigration); err != nil {
+					return m.fail(ctx, tx, mode, index, direction, err)
+				}
+				if err := m.hooks.afterDown.call(ctx, tx, index, direction); err != nil {
+					return m.fail(ctx, tx, mode, index, direction, err)
 				}
 			} else {
 				m.logger.Infof("skipping unpassed version %d...", index)
@@ -247,23 +648,15 @@ func (m *manager) run(ctx context.Context, direction Direction, mode Transaction
 	return nil
 }
 
-func sortMigrations(versions []Version, dir Direction) []Version {
-	sort.SliceStable(versions, func(i, j int) bool {
-		indexI, err := versionIndex(versions[i])
-		if err != nil {
-			panic(err)
-		}
-		indexJ, err := versionIndex(versions[j])
-		if err != nil {
-			panic(err)
-		}
+func sortMigrations(migrations []Migration, dir Direction) []Migration {
+	sort.SliceStable(migrations, func(i, j int) bool {
 		if dir == DirectionUp {
-			return indexI < indexJ // up
+			return migrations[i].Version < migrations[j].Version // up
 		}
-		return indexI > indexJ // down
+		return migrations[i].Version > migrations[j].Version // down
 	})
 
-	return versions
+	return migrations
 }
 
 func toMap(passedMigrations []PassedMigration) map[uint64]PassedMigration {
@@ -274,25 +667,96 @@ func toMap(passedMigrations []PassedMigration) map[uint64]PassedMigration {
 	return res
 }
 
+// versionIndex extracts a migration's version number from its Go type
+// name, e.g. Version20210909134215 -> 20210909134215.
+//
+// Deprecated: prefer Register/RegisterSQL, which take the version
+// explicitly instead of parsing it out of reflect.Type.Name().
 func versionIndex(version Version) (uint64, error) {
 	ti := reflect.TypeOf(version)
 	return strconv.ParseUint(strings.Replace(ti.Name(), "Version", "", -1), 10, 64)
 }
 
 func (m *manager) createTableIfNotExists() error {
-	_, err := m.conn.Exec(fmt.Sprintf(`CREATE TABLE IF NOT EXISTS "%s" (version BIGINT UNIQUE, created_at TIMESTAMP);`, m.tableName))
+	_, err := m.conn.Exec(m.dialect.CreateVersionTable(m.tableName))
+	return err
+}
+
+// acquireLock takes a session-scoped advisory lock on a dedicated
+// connection, held for the whole run() invocation, when m.lockStrategy
+// is LockAdvisory. It returns a release func that must always be called,
+// even when locking was a no-op (LockTable/LockNone, or a dialect with
+// no advisory lock support).
+func (m *manager) acquireLock(ctx context.Context) (func(), error) {
+	noop := func() {}
+	if m.lockStrategy != LockAdvisory {
+		return noop, nil
+	}
+	query, arg, check, ok := m.dialect.AdvisoryLock(m.tableName)
+	if !ok {
+		m.logger.Infof("migration: dialect %s has no advisory lock, skipping", m.dialect.Name())
+		return noop, nil
+	}
+	conn, err := m.conn.Connx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if check == nil {
+		if _, err := conn.ExecContext(ctx, query, arg); err != nil {
+			_ = conn.Close()
+			return nil, err
+		}
+	} else {
+		var result interface{}
+		if err := conn.QueryRowContext(ctx, query, arg).Scan(&result); err != nil {
+			_ = conn.Close()
+			return nil, err
+		}
+		if err := check(result); err != nil {
+			_ = conn.Close()
+			return nil, err
+		}
+	}
+	return func() {
+		if unlockQuery, unlockArg, ok := m.dialect.AdvisoryUnlock(m.tableName); ok {
+			if _, err := conn.ExecContext(ctx, unlockQuery, unlockArg); err != nil {
+				m.logger.Errorf("migration: advisory unlock err: %s", err)
+			}
+		}
+		if err := conn.Close(); err != nil {
+			m.logger.Errorf("migration: lock connection close err: %s", err)
+		}
+	}, nil
+}
+
+// fail reports err via the OnError hook and, in TransactionModeIndividual,
+// rolls back the current migration's transaction instead of leaving it
+// for the caller to commit.
+func (m *manager) fail(ctx context.Context, tx *sqlx.Tx, mode TransactionMode, version uint64, direction Direction, err error) error {
+	if m.hooks.onError != nil {
+		m.hooks.onError(ctx, tx, version, direction, err)
+	}
+	if mode == TransactionModeIndividual && tx != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			m.logger.Errorf("migration: rollback err: %s", rbErr)
+		}
+	}
 	return err
 }
 
 func (m *manager) lockTable(tx *sqlx.Tx) error {
-	_, err := tx.Exec(fmt.Sprintf(`LOCK TABLE "%s" IN SHARE MODE;`, m.tableName))
+	query, ok := m.dialect.LockTable(m.tableName)
+	if !ok {
+		m.logger.Infof("migration: dialect %s has no table lock, skipping", m.dialect.Name())
+		return nil
+	}
+	_, err := tx.Exec(query)
 	return err
 }
 
 func (m *manager) findAll() ([]PassedMigration, error) {
 	var entities []PassedMigration
-	query := fmt.Sprintf(`SELECT version, created_at FROM "%s";`, m.tableName)
-	rows, err := m.conn.Query(query)
+	rows, err := m.conn.Query(m.dialect.ListMigrations(m.tableName))
 	if err != nil {
 		return nil, err
 	}
@@ -313,13 +777,11 @@ func (m *manager) findAll() ([]PassedMigration, error) {
 }
 
 func (m *manager) insert(tx *sqlx.Tx, entity PassedMigration) error {
-	query := fmt.Sprintf(`INSERT INTO "%s" (version, created_at) VALUES ($1, $2);`, m.tableName)
-	_, err := tx.Exec(query, entity.Version, entity.CreatedAt)
+	_, err := tx.Exec(m.dialect.InsertVersion(m.tableName), entity.Version, entity.CreatedAt)
 	return err
 }
 
 func (m *manager) delete(tx *sqlx.Tx, entity PassedMigration) error {
-	query := fmt.Sprintf(`DELETE FROM "%s" WHERE version = $1;`, m.tableName)
-	_, err := tx.Exec(query, entity.Version)
+	_, err := tx.Exec(m.dialect.DeleteVersion(m.tableName), entity.Version)
 	return err
 }
@@ -0,0 +1,200 @@
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"sync/atomic"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// testLogger discards everything; acquireLock only logs informational
+// no-op cases this file isn't asserting on.
+type testLogger struct{}
+
+func (testLogger) Infof(string, ...interface{})  {}
+func (testLogger) Errorf(string, ...interface{}) {}
+
+// testDialect is a minimal dialect.Store stub that only wires up the
+// AdvisoryLock/AdvisoryUnlock behavior a given test needs; the other
+// methods are never exercised by acquireLock.
+type testDialect struct {
+	lockQuery string
+	lockArg   interface{}
+	lockCheck func(interface{}) error
+	lockOK    bool
+}
+
+func (testDialect) Name() string                      { return "test" }
+func (testDialect) CreateVersionTable(string) string   { return "" }
+func (testDialect) InsertVersion(string) string        { return "" }
+func (testDialect) DeleteVersion(string) string        { return "" }
+func (testDialect) ListMigrations(string) string       { return "" }
+func (testDialect) LockTable(string) (string, bool)    { return "", false }
+func (testDialect) AdvisoryUnlock(string) (string, interface{}, bool) { return "", nil, false }
+
+func (d testDialect) AdvisoryLock(string) (string, interface{}, func(interface{}) error, bool) {
+	return d.lockQuery, d.lockArg, d.lockCheck, d.lockOK
+}
+
+// fakeDriver is a database/sql driver whose every query returns a single
+// row containing result (or fails with queryErr), so acquireLock's
+// QueryRowContext path can be exercised without a real database.
+type fakeDriver struct {
+	result   interface{}
+	queryErr error
+}
+
+func (d *fakeDriver) Open(string) (driver.Conn, error) { return fakeConn{driver: d}, nil }
+
+type fakeConn struct{ driver *fakeDriver }
+
+func (c fakeConn) Prepare(query string) (driver.Stmt, error) { return fakeStmt{conn: c}, nil }
+func (c fakeConn) Close() error                              { return nil }
+func (c fakeConn) Begin() (driver.Tx, error)                 { return nil, errors.New("fakeConn: transactions unsupported") }
+
+type fakeStmt struct{ conn fakeConn }
+
+func (s fakeStmt) Close() error  { return nil }
+func (s fakeStmt) NumInput() int { return -1 }
+
+func (s fakeStmt) Exec([]driver.Value) (driver.Result, error) {
+	if s.conn.driver.queryErr != nil {
+		return nil, s.conn.driver.queryErr
+	}
+	return driver.RowsAffected(0), nil
+}
+
+func (s fakeStmt) Query([]driver.Value) (driver.Rows, error) {
+	if s.conn.driver.queryErr != nil {
+		return nil, s.conn.driver.queryErr
+	}
+	return &fakeRows{value: s.conn.driver.result}, nil
+}
+
+type fakeRows struct {
+	value interface{}
+	done  bool
+}
+
+func (r *fakeRows) Columns() []string { return []string{"result"} }
+func (r *fakeRows) Close() error      { return nil }
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.done {
+		return io.EOF
+	}
+	r.done = true
+	dest[0] = r.value
+	return nil
+}
+
+var fakeDriverSeq int32
+
+// newFakeDB registers a fresh fakeDriver under a unique name and returns
+// a *sqlx.DB backed by it, so each test gets an isolated driver instance.
+func newFakeDB(t *testing.T, d *fakeDriver) *sqlx.DB {
+	t.Helper()
+	name := fmt.Sprintf("migrate-fake-driver-%d", atomic.AddInt32(&fakeDriverSeq, 1))
+	sql.Register(name, d)
+	db, err := sqlx.Open(name, "fake")
+	if err != nil {
+		t.Fatalf("sqlx.Open() error = %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+	return db
+}
+
+func TestAcquireLockSucceedsWhenCheckAcceptsResult(t *testing.T) {
+	db := newFakeDB(t, &fakeDriver{result: int64(1)})
+	mgr := NewManager("", "", testLogger{}, "schema_migrations", db,
+		WithDialect(testDialect{
+			lockQuery: "SELECT GET_LOCK(?, -1);",
+			lockArg:   "key",
+			lockCheck: func(v interface{}) error {
+				if v == int64(1) {
+					return nil
+				}
+				return fmt.Errorf("GET_LOCK did not return 1 (got %v)", v)
+			},
+			lockOK: true,
+		}),
+		WithLockStrategy(LockAdvisory),
+	).(*manager)
+
+	release, err := mgr.acquireLock(context.Background())
+	if err != nil {
+		t.Fatalf("acquireLock() error = %v, want nil", err)
+	}
+	release()
+}
+
+// TestAcquireLockFailsWhenCheckRejectsResult is the regression case for
+// the bug acquireLock used to have: a GET_LOCK/sp_getapplock call that
+// succeeds at the driver level (no ExecContext error) but whose result
+// indicates the lock was not actually acquired must make acquireLock
+// fail, not silently let the caller proceed.
+func TestAcquireLockFailsWhenCheckRejectsResult(t *testing.T) {
+	db := newFakeDB(t, &fakeDriver{result: nil}) // GET_LOCK returns NULL on failure
+	mgr := NewManager("", "", testLogger{}, "schema_migrations", db,
+		WithDialect(testDialect{
+			lockQuery: "SELECT GET_LOCK(?, -1);",
+			lockArg:   "key",
+			lockCheck: func(v interface{}) error {
+				if v == int64(1) {
+					return nil
+				}
+				return fmt.Errorf("GET_LOCK did not return 1 (got %v)", v)
+			},
+			lockOK: true,
+		}),
+		WithLockStrategy(LockAdvisory),
+	).(*manager)
+
+	if _, err := mgr.acquireLock(context.Background()); err == nil {
+		t.Fatal("acquireLock() error = nil, want an error since the lock check rejected the result")
+	}
+}
+
+func TestAcquireLockNoopWhenDialectHasNoAdvisoryLock(t *testing.T) {
+	mgr := NewManager("", "", testLogger{}, "schema_migrations", nil,
+		WithDialect(testDialect{lockOK: false}),
+		WithLockStrategy(LockAdvisory),
+	).(*manager)
+
+	release, err := mgr.acquireLock(context.Background())
+	if err != nil {
+		t.Fatalf("acquireLock() error = %v, want nil", err)
+	}
+	release()
+}
+
+func TestAcquireLockNoopWhenLockStrategyIsNotAdvisory(t *testing.T) {
+	mgr := NewManager("", "", testLogger{}, "schema_migrations", nil,
+		WithLockStrategy(LockNone),
+	).(*manager)
+
+	release, err := mgr.acquireLock(context.Background())
+	if err != nil {
+		t.Fatalf("acquireLock() error = %v, want nil", err)
+	}
+	release()
+}
+
+func TestNewManagerDefaults(t *testing.T) {
+	mgr := NewManager("", "", testLogger{}, "", nil).(*manager)
+	if mgr.tableName != defTableName {
+		t.Fatalf("tableName = %q, want %q", mgr.tableName, defTableName)
+	}
+	if mgr.lockStrategy != LockAdvisory {
+		t.Fatalf("lockStrategy = %v, want LockAdvisory", mgr.lockStrategy)
+	}
+	if mgr.dialect.Name() != "postgres" {
+		t.Fatalf("dialect = %q, want %q", mgr.dialect.Name(), "postgres")
+	}
+}
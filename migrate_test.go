@@ -0,0 +1,106 @@
+package migrate
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// withCleanRegistry runs fn with the package-level registry reset
+// beforehand and restored afterward, so Register/RegisterSQL calls in one
+// test don't leak into another.
+func withCleanRegistry(t *testing.T, fn func()) {
+	t.Helper()
+	saved := registry
+	registry = nil
+	t.Cleanup(func() { registry = saved })
+	fn()
+}
+
+func TestRegistrySourceDuplicateVersion(t *testing.T) {
+	withCleanRegistry(t, func() {
+		noop := func(*sqlx.Tx) error { return nil }
+		Register(20210909134215, "add_users", noop, noop)
+		Register(20210909134215, "add_orders", noop, noop)
+
+		if _, err := (registrySource{}).Migrations(); err == nil {
+			t.Fatal("Migrations() error = nil, want duplicate version error")
+		}
+	})
+}
+
+func TestRegistrySourceOrderIndependent(t *testing.T) {
+	withCleanRegistry(t, func() {
+		noop := func(*sqlx.Tx) error { return nil }
+		Register(20210909134300, "add_orders", noop, noop)
+		Register(20210909134215, "add_users", noop, noop)
+
+		migrations, err := (registrySource{}).Migrations()
+		if err != nil {
+			t.Fatalf("Migrations() error = %v", err)
+		}
+		if len(migrations) != 2 {
+			t.Fatalf("len(migrations) = %d, want 2", len(migrations))
+		}
+	})
+}
+
+type Version20210909134215 struct{}
+
+func (Version20210909134215) Up(*sqlx.Tx) error   { return nil }
+func (Version20210909134215) Down(*sqlx.Tx) error { return nil }
+
+func TestVersionIndex(t *testing.T) {
+	index, err := versionIndex(Version20210909134215{})
+	if err != nil {
+		t.Fatalf("versionIndex() error = %v", err)
+	}
+	if index != 20210909134215 {
+		t.Fatalf("versionIndex() = %d, want 20210909134215", index)
+	}
+}
+
+func TestSortMigrations(t *testing.T) {
+	migrations := []Migration{{Version: 3}, {Version: 1}, {Version: 2}}
+
+	up := sortMigrations(append([]Migration(nil), migrations...), DirectionUp)
+	for i, want := range []uint64{1, 2, 3} {
+		if up[i].Version != want {
+			t.Fatalf("DirectionUp[%d] = %d, want %d", i, up[i].Version, want)
+		}
+	}
+
+	down := sortMigrations(append([]Migration(nil), migrations...), DirectionDown)
+	for i, want := range []uint64{3, 2, 1} {
+		if down[i].Version != want {
+			t.Fatalf("DirectionDown[%d] = %d, want %d", i, down[i].Version, want)
+		}
+	}
+}
+
+func TestToMap(t *testing.T) {
+	m := toMap([]PassedMigration{{Version: 1}, {Version: 2}})
+	if len(m) != 2 {
+		t.Fatalf("len(toMap()) = %d, want 2", len(m))
+	}
+	if _, ok := m[1]; !ok {
+		t.Fatal("toMap() missing version 1")
+	}
+}
+
+func TestHookFuncCallNilIsNoop(t *testing.T) {
+	var fn HookFunc
+	if err := fn.call(context.Background(), nil, 1, DirectionUp); err != nil {
+		t.Fatalf("nil HookFunc.call() error = %v, want nil", err)
+	}
+}
+
+func TestHookFuncCallPropagatesError(t *testing.T) {
+	want := errors.New("boom")
+	fn := HookFunc(func(context.Context, *sqlx.Tx, uint64, Direction) error { return want })
+	if err := fn.call(context.Background(), nil, 1, DirectionUp); !errors.Is(err, want) {
+		t.Fatalf("HookFunc.call() error = %v, want %v", err, want)
+	}
+}
@@ -0,0 +1,171 @@
+// Package source reads migrations from plain .sql files on any fs.FS,
+// as an alternative to compiled Go migrations (migrate.GoSource).
+package source
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/slmder/migrate"
+)
+
+var (
+	pairFileName   = regexp.MustCompile(`^(\d+)_([^.]+)\.(up|down)\.sql$`)
+	singleFileName = regexp.MustCompile(`^(\d+)_([^.]+)\.sql$`)
+)
+
+var errNoDirectionMarker = errors.New("source: file has neither -- +migrate Up nor -- +migrate Down marker")
+
+// FSSource reads migrations from fsys under dir, recognizing either a
+// pair of files named "<version>_<name>.up.sql" / "<version>_<name>.down.sql",
+// or a single "<version>_<name>.sql" file containing both directions
+// separated by "-- +migrate Up" / "-- +migrate Down" markers.
+type FSSource struct {
+	fsys fs.FS
+	dir  string
+}
+
+// NewFSSource returns a Source reading migration files under dir in
+// fsys, e.g. source.NewFSSource(os.DirFS("."), "migrations") or an
+// embed.FS passed in by the caller.
+func NewFSSource(fsys fs.FS, dir string) *FSSource {
+	return &FSSource{fsys: fsys, dir: dir}
+}
+
+type sqlParts struct {
+	name    string
+	up      string
+	down    string
+	hasUp   bool
+	hasDown bool
+}
+
+// Migrations errors if two files claim the same version, or a
+// "<version>_<name>.up.sql" / ".down.sql" pair is missing one of its
+// halves, rather than silently dropping a migration or treating a
+// missing half as a no-op.
+func (s *FSSource) Migrations() ([]migrate.Migration, error) {
+	entries, err := fs.ReadDir(s.fsys, s.dir)
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := map[uint64]*sqlParts{}
+	claimedBy := map[uint64]string{}
+	var versions []uint64
+
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		fileName := entry.Name()
+
+		if m := pairFileName.FindStringSubmatch(fileName); m != nil {
+			version, err := strconv.ParseUint(m[1], 10, 64)
+			if err != nil {
+				return nil, err
+			}
+			content, err := fs.ReadFile(s.fsys, path.Join(s.dir, fileName))
+			if err != nil {
+				return nil, err
+			}
+			p, ok := byVersion[version]
+			if !ok {
+				p = &sqlParts{name: m[2]}
+				byVersion[version] = p
+				claimedBy[version] = fileName
+				versions = append(versions, version)
+			} else if p.name != m[2] {
+				return nil, fmt.Errorf("source: duplicate version %d (%s and %s)", version, claimedBy[version], fileName)
+			}
+			if m[3] == "up" {
+				if p.hasUp {
+					return nil, fmt.Errorf("source: duplicate version %d (%s and %s)", version, claimedBy[version], fileName)
+				}
+				p.up, p.hasUp = string(content), true
+			} else {
+				if p.hasDown {
+					return nil, fmt.Errorf("source: duplicate version %d (%s and %s)", version, claimedBy[version], fileName)
+				}
+				p.down, p.hasDown = string(content), true
+			}
+			continue
+		}
+
+		if m := singleFileName.FindStringSubmatch(fileName); m != nil {
+			version, err := strconv.ParseUint(m[1], 10, 64)
+			if err != nil {
+				return nil, err
+			}
+			if _, exists := byVersion[version]; exists {
+				return nil, fmt.Errorf("source: duplicate version %d (%s and %s)", version, claimedBy[version], fileName)
+			}
+			content, err := fs.ReadFile(s.fsys, path.Join(s.dir, fileName))
+			if err != nil {
+				return nil, err
+			}
+			up, down, err := splitUpDown(string(content))
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", fileName, err)
+			}
+			byVersion[version] = &sqlParts{name: m[2], up: up, down: down, hasUp: true, hasDown: true}
+			claimedBy[version] = fileName
+			versions = append(versions, version)
+		}
+	}
+
+	sort.Slice(versions, func(i, j int) bool { return versions[i] < versions[j] })
+
+	migrations := make([]migrate.Migration, 0, len(versions))
+	for _, version := range versions {
+		p := byVersion[version]
+		if !p.hasUp || !p.hasDown {
+			half := "up"
+			if p.hasUp {
+				half = "down"
+			}
+			return nil, fmt.Errorf("source: version %d (%s) missing %s.sql half", version, p.name, half)
+		}
+		migrations = append(migrations, migrate.Migration{
+			Version: version,
+			Name:    p.name,
+			Source:  "sql",
+			Up:      execScript(p.up),
+			Down:    execScript(p.down),
+		})
+	}
+	return migrations, nil
+}
+
+func execScript(script string) func(tx *sqlx.Tx) error {
+	return func(tx *sqlx.Tx) error {
+		for _, stmt := range migrate.SplitStatements(script) {
+			if _, err := tx.Exec(stmt); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+func splitUpDown(content string) (up string, down string, err error) {
+	const upMarker = "-- +migrate Up"
+	const downMarker = "-- +migrate Down"
+
+	upIdx := strings.Index(content, upMarker)
+	downIdx := strings.Index(content, downMarker)
+	if upIdx == -1 || downIdx == -1 {
+		return "", "", errNoDirectionMarker
+	}
+	if upIdx < downIdx {
+		return content[upIdx+len(upMarker) : downIdx], content[downIdx+len(downMarker):], nil
+	}
+	return content[upIdx+len(upMarker):], content[downIdx+len(downMarker) : upIdx], nil
+}
@@ -0,0 +1,59 @@
+package source
+
+import (
+	"testing"
+	"testing/fstest"
+)
+
+func TestFSSourceMigrations(t *testing.T) {
+	fsys := fstest.MapFS{
+		"migrations/20210909134215_add_users.up.sql":   {Data: []byte("CREATE TABLE users (id int);")},
+		"migrations/20210909134215_add_users.down.sql": {Data: []byte("DROP TABLE users;")},
+		"migrations/20210909134300_add_orders.sql": {Data: []byte(
+			"-- +migrate Up\nCREATE TABLE orders (id int);\n-- +migrate Down\nDROP TABLE orders;",
+		)},
+	}
+
+	src := NewFSSource(fsys, "migrations")
+	migrations, err := src.Migrations()
+	if err != nil {
+		t.Fatalf("Migrations() error = %v", err)
+	}
+	if len(migrations) != 2 {
+		t.Fatalf("len(migrations) = %d, want 2", len(migrations))
+	}
+	if migrations[0].Version != 20210909134215 || migrations[1].Version != 20210909134300 {
+		t.Fatalf("unexpected version order: %d, %d", migrations[0].Version, migrations[1].Version)
+	}
+	for _, m := range migrations {
+		if m.Up == nil || m.Down == nil {
+			t.Fatalf("migration %d missing Up/Down func", m.Version)
+		}
+	}
+}
+
+func TestFSSourceMigrationsDuplicateVersion(t *testing.T) {
+	fsys := fstest.MapFS{
+		"migrations/20210909134215_add_users.up.sql":   {Data: []byte("CREATE TABLE users (id int);")},
+		"migrations/20210909134215_add_users.down.sql": {Data: []byte("DROP TABLE users;")},
+		"migrations/20210909134215_add_orders.sql": {Data: []byte(
+			"-- +migrate Up\nCREATE TABLE orders (id int);\n-- +migrate Down\nDROP TABLE orders;",
+		)},
+	}
+
+	src := NewFSSource(fsys, "migrations")
+	if _, err := src.Migrations(); err == nil {
+		t.Fatal("Migrations() error = nil, want duplicate version error")
+	}
+}
+
+func TestFSSourceMigrationsMissingHalf(t *testing.T) {
+	fsys := fstest.MapFS{
+		"migrations/20210909134215_add_users.up.sql": {Data: []byte("CREATE TABLE users (id int);")},
+	}
+
+	src := NewFSSource(fsys, "migrations")
+	if _, err := src.Migrations(); err == nil {
+		t.Fatal("Migrations() error = nil, want missing half error")
+	}
+}
@@ -0,0 +1,60 @@
+package migrate
+
+import "strings"
+
+const (
+	stmtBeginMarker = "-- +migrate StatementBegin"
+	stmtEndMarker   = "-- +migrate StatementEnd"
+)
+
+// SplitStatements splits a SQL script into individual statements on
+// semicolons, while keeping a $$ ... $$ dollar-quoted block (a Postgres
+// function body) or a region wrapped in "-- +migrate StatementBegin" /
+// "-- +migrate StatementEnd" markers as a single statement even when it
+// contains semicolons of its own. Used by RegisterSQL and by FSSource in
+// the source package.
+func SplitStatements(script string) []string {
+	lines := strings.Split(script, "\n")
+	var statements []string
+	var current strings.Builder
+	inCustomBlock := false
+	inDollarQuote := false
+
+	flush := func() {
+		if s := strings.TrimSpace(current.String()); s != "" {
+			statements = append(statements, s)
+		}
+		current.Reset()
+	}
+
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		switch trimmed {
+		case stmtBeginMarker:
+			inCustomBlock = true
+			continue
+		case stmtEndMarker:
+			inCustomBlock = false
+			flush()
+			continue
+		}
+
+		current.WriteString(line)
+		current.WriteByte('\n')
+
+		if strings.Count(line, "$$")%2 == 1 {
+			inDollarQuote = !inDollarQuote
+		}
+
+		if inCustomBlock || inDollarQuote {
+			continue
+		}
+
+		if strings.HasSuffix(trimmed, ";") {
+			flush()
+		}
+	}
+	flush()
+
+	return statements
+}
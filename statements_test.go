@@ -0,0 +1,59 @@
+package migrate
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSplitStatements(t *testing.T) {
+	tests := []struct {
+		name   string
+		script string
+		want   []string
+	}{
+		{
+			name:   "simple statements",
+			script: "CREATE TABLE a (id int);\nCREATE TABLE b (id int);",
+			want: []string{
+				"CREATE TABLE a (id int);",
+				"CREATE TABLE b (id int);",
+			},
+		},
+		{
+			name: "dollar quoted function body keeps its semicolons",
+			script: "CREATE FUNCTION f() RETURNS void AS $$\n" +
+				"BEGIN\n" +
+				"  INSERT INTO a VALUES (1);\n" +
+				"  INSERT INTO a VALUES (2);\n" +
+				"END;\n" +
+				"$$ LANGUAGE plpgsql;\n" +
+				"CREATE TABLE c (id int);",
+			want: []string{
+				"CREATE FUNCTION f() RETURNS void AS $$\nBEGIN\n  INSERT INTO a VALUES (1);\n  INSERT INTO a VALUES (2);\nEND;\n$$ LANGUAGE plpgsql;",
+				"CREATE TABLE c (id int);",
+			},
+		},
+		{
+			name: "StatementBegin/End block keeps its semicolons",
+			script: "-- +migrate StatementBegin\n" +
+				"CREATE TRIGGER t BEFORE INSERT ON a FOR EACH ROW BEGIN\n" +
+				"  SET NEW.created_at = NOW();\n" +
+				"END;\n" +
+				"-- +migrate StatementEnd\n" +
+				"CREATE TABLE d (id int);",
+			want: []string{
+				"CREATE TRIGGER t BEFORE INSERT ON a FOR EACH ROW BEGIN\n  SET NEW.created_at = NOW();\nEND;",
+				"CREATE TABLE d (id int);",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := SplitStatements(tt.script)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("SplitStatements() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}